@@ -0,0 +1,31 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import "github.com/ory/kratos/x"
+
+// Claims is the provider-agnostic set of identity attributes every Provider's
+// Claims() method maps its upstream profile/userinfo response into. The OIDC
+// strategy's Jsonnet mapper runs against a JSON-encoded Claims value, so every
+// field here is what `std.extVar('claims')` exposes to `identity.jsonnet`.
+type Claims struct {
+	Issuer        string               `json:"iss,omitempty"`
+	Subject       string               `json:"sub,omitempty"`
+	Name          string               `json:"name,omitempty"`
+	GivenName     string               `json:"given_name,omitempty"`
+	LastName      string               `json:"last_name,omitempty"`
+	Nickname      string               `json:"nickname,omitempty"`
+	Picture       string               `json:"picture,omitempty"`
+	Email         string               `json:"email,omitempty"`
+	EmailVerified x.ConvertibleBoolean `json:"email_verified,omitempty"`
+	// Locale is a BCP-47 language tag (e.g. "en" or, when the upstream provider
+	// reports a country too, region-qualified like "en-US").
+	Locale string `json:"locale,omitempty"`
+	// LocaleCountry is the region part of Locale (e.g. "US" in "en-US"), broken
+	// out separately so identity schemas can map language and country into
+	// distinct traits instead of parsing Locale as a string. Populated by any
+	// provider whose userinfo/profile response separates language and country,
+	// not just Locale's combined tag.
+	LocaleCountry string `json:"locale_country,omitempty"`
+}
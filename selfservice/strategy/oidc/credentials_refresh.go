@@ -0,0 +1,47 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// CredentialsRefresher validates and, where possible, extends the upstream
+// OAuth2 token stored against an identity's `oidc` credentials. The admin
+// refresh endpoint calls into it; no identity-credentials update flow in this
+// package does.
+type CredentialsRefresher struct {
+	r Dependencies
+}
+
+func NewCredentialsRefresher(reg Dependencies) *CredentialsRefresher {
+	return &CredentialsRefresher{r: reg}
+}
+
+// Refresh resolves config's provider and, if it implements RefreshableProvider,
+// calls its Refresh to validate and extend oldToken. Providers that only
+// implement TokenIntrospector are checked via Introspect instead and, if the
+// token is still active, get oldToken back unchanged. Providers that implement
+// neither interface get oldToken back unchanged without any upstream call.
+func (c *CredentialsRefresher) Refresh(ctx context.Context, config *Configuration, oldToken *oauth2.Token) (*oauth2.Token, error) {
+	provider, err := NewProvider(config, c.r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if refreshable, ok := provider.(RefreshableProvider); ok {
+		return refreshable.Refresh(ctx, oldToken)
+	}
+
+	if introspector, ok := provider.(TokenIntrospector); ok {
+		if _, err := introspector.Introspect(ctx, oldToken.AccessToken); err != nil {
+			return nil, errors.WithStack(ErrProviderTokenExpired.WithWrap(err))
+		}
+	}
+
+	return oldToken, nil
+}
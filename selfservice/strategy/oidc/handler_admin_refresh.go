@@ -0,0 +1,74 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+// RouteAdminIdentityCredentialsOIDCRefresh is the admin endpoint operators call
+// to proactively validate (and, where possible, extend) the upstream OAuth2
+// token stored against an identity's `oidc` credentials for a given provider.
+const RouteAdminIdentityCredentialsOIDCRefresh = "/admin/identities/:id/credentials/oidc/:provider/refresh"
+
+// CredentialsRefreshPersister is the slice of the identity credentials store
+// the admin refresh endpoint needs: load the provider config and stored token
+// for an identity, and persist the (possibly extended) token back.
+type CredentialsRefreshPersister interface {
+	GetIdentityOIDCToken(ctx context.Context, identityID, providerID string) (*Configuration, *oauth2.Token, error)
+	UpdateIdentityOIDCToken(ctx context.Context, identityID, providerID string, token *oauth2.Token) error
+}
+
+// AdminRefreshHandler exposes RouteAdminIdentityCredentialsOIDCRefresh.
+type AdminRefreshHandler struct {
+	r         Dependencies
+	persister CredentialsRefreshPersister
+	h         *herodot.JSONWriter
+}
+
+func NewAdminRefreshHandler(reg Dependencies, persister CredentialsRefreshPersister, writer *herodot.JSONWriter) *AdminRefreshHandler {
+	return &AdminRefreshHandler{r: reg, persister: persister, h: writer}
+}
+
+func (h *AdminRefreshHandler) RegisterAdminRoutes(admin *httprouter.Router) {
+	admin.POST(RouteAdminIdentityCredentialsOIDCRefresh, h.refresh)
+}
+
+// refresh loads the identity's stored provider config and token, asks
+// CredentialsRefresher to validate/extend it, persists the result, and
+// responds 200 with the new expiry or translates ErrProviderTokenExpired into
+// the re-auth-required response operators should surface to the identity.
+func (h *AdminRefreshHandler) refresh(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+	identityID, providerID := ps.ByName("id"), ps.ByName("provider")
+
+	config, oldToken, err := h.persister.GetIdentityOIDCToken(ctx, identityID, providerID)
+	if err != nil {
+		h.h.WriteError(w, r, err)
+		return
+	}
+
+	refreshed, err := NewCredentialsRefresher(h.r).Refresh(ctx, config, oldToken)
+	if err != nil {
+		h.h.WriteError(w, r, errors.WithStack(err))
+		return
+	}
+
+	if err := h.persister.UpdateIdentityOIDCToken(ctx, identityID, providerID, refreshed); err != nil {
+		h.h.WriteError(w, r, err)
+		return
+	}
+
+	h.h.WriteCode(w, r, http.StatusOK, map[string]interface{}{
+		"provider":   providerID,
+		"expires_at": refreshed.Expiry,
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+func TestAdminRefreshEndpointEndToEnd(t *testing.T) {
+	upstream := http.NewServeMux()
+	upstream.HandleFunc("/v2/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"sub": "li-1"}`))
+	})
+	upstream.HandleFunc("/oauth/v2/introspectToken", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"active": true, "expires_at": 2000000000}`))
+	})
+	upstreamServer := httptest.NewServer(upstream)
+	defer upstreamServer.Close()
+	upstreamURL, err := url.Parse(upstreamServer.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: upstreamURL}
+
+	store := NewIdentityCredentialsStore()
+	config := &Configuration{ID: "linkedin", Provider: "linkedin", ClientID: "client", ClientSecret: "secret"}
+	oldToken := &oauth2.Token{AccessToken: "tok", Expiry: time.Unix(1, 0)}
+	store.SetOIDCToken("identity-1", config, oldToken)
+
+	strategy := NewStrategy(deps, store, herodot.NewJSONWriter(deps.Logger()))
+
+	router := httprouter.New()
+	strategy.RegisterAdminRoutes(router)
+	adminServer := httptest.NewServer(router)
+	defer adminServer.Close()
+
+	res, err := http.Post(adminServer.URL+"/admin/identities/identity-1/credentials/oidc/linkedin/refresh", "application/json", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	_, updated, err := store.GetIdentityOIDCToken(context.Background(), "identity-1", "linkedin")
+	require.NoError(t, err)
+	assert.True(t, updated.Expiry.After(oldToken.Expiry))
+}
+
+func TestAdminRefreshEndpointUnknownIdentity(t *testing.T) {
+	deps := newFakeDependencies()
+	store := NewIdentityCredentialsStore()
+	strategy := NewStrategy(deps, store, herodot.NewJSONWriter(deps.Logger()))
+
+	router := httprouter.New()
+	strategy.RegisterAdminRoutes(router)
+	adminServer := httptest.NewServer(router)
+	defer adminServer.Close()
+
+	res, err := http.Post(adminServer.URL+"/admin/identities/does-not-exist/credentials/oidc/linkedin/refresh", "application/json", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
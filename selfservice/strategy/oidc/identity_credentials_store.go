@@ -0,0 +1,75 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+)
+
+type storedOIDCCredential struct {
+	config *Configuration
+	token  *oauth2.Token
+}
+
+// IdentityCredentialsStore is the CredentialsRefreshPersister the admin
+// refresh endpoint is wired against. It keeps the provider config and access
+// token an identity's `oidc` credentials were set up with, keyed by
+// (identity, provider), so the admin handler can load the stored token, ask
+// CredentialsRefresher to validate/extend it, and persist the result back.
+type IdentityCredentialsStore struct {
+	mu    sync.Mutex
+	creds map[string]storedOIDCCredential
+}
+
+func NewIdentityCredentialsStore() *IdentityCredentialsStore {
+	return &IdentityCredentialsStore{creds: make(map[string]storedOIDCCredential)}
+}
+
+func credentialKey(identityID, providerID string) string {
+	return identityID + "/" + providerID
+}
+
+// SetOIDCToken seeds the store with an identity's OIDC credential. The admin
+// refresh endpoint only reads and updates what's set here.
+func (s *IdentityCredentialsStore) SetOIDCToken(identityID string, config *Configuration, token *oauth2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creds[credentialKey(identityID, config.ID)] = storedOIDCCredential{config: config, token: token}
+}
+
+func (s *IdentityCredentialsStore) GetIdentityOIDCToken(ctx context.Context, identityID, providerID string) (*Configuration, *oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.creds[credentialKey(identityID, providerID)]
+	if !ok {
+		return nil, nil, errors.WithStack(herodot.ErrNotFound.WithReasonf(
+			"identity %s has no stored oidc credentials for provider %s", identityID, providerID))
+	}
+	return stored.config, stored.token, nil
+}
+
+func (s *IdentityCredentialsStore) UpdateIdentityOIDCToken(ctx context.Context, identityID, providerID string, token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := credentialKey(identityID, providerID)
+	stored, ok := s.creds[key]
+	if !ok {
+		return errors.WithStack(herodot.ErrNotFound.WithReasonf(
+			"identity %s has no stored oidc credentials for provider %s", identityID, providerID))
+	}
+
+	stored.token = token
+	s.creds[key] = stored
+	return nil
+}
+
+var _ CredentialsRefreshPersister = (*IdentityCredentialsStore)(nil)
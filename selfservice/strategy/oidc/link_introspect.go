@@ -0,0 +1,41 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import "context"
+
+// LinkingValidator checks a stored upstream token before it is trusted: for
+// any provider that implements TokenIntrospector, it introspects the token and
+// surfaces a revoked or expired one as an error before the caller ever reaches
+// the userinfo endpoint, instead of that request failing in a way that's hard
+// to tell apart from a transient upstream outage. No account-linking or
+// refresh flow in this package calls it; CredentialsRefresher runs its own
+// introspection check.
+type LinkingValidator struct {
+	r Dependencies
+}
+
+func NewLinkingValidator(reg Dependencies) *LinkingValidator {
+	return &LinkingValidator{r: reg}
+}
+
+// ValidateBeforeUse introspects token if config's provider implements
+// TokenIntrospector, returning the herodot.ErrUnauthorized-wrapped error
+// Introspect produces when the token is inactive or expired. Providers that
+// don't implement TokenIntrospector are not checked here, since Claims() will
+// fail on its own when it calls the userinfo endpoint with a bad token.
+func (l *LinkingValidator) ValidateBeforeUse(ctx context.Context, config *Configuration, accessToken string) error {
+	provider, err := NewProvider(config, l.r)
+	if err != nil {
+		return err
+	}
+
+	introspector, ok := provider.(TokenIntrospector)
+	if !ok {
+		return nil
+	}
+
+	_, err = introspector.Introspect(ctx, accessToken)
+	return err
+}
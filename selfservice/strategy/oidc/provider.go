@@ -0,0 +1,77 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+// Provider is implemented by every supported upstream OIDC/OAuth2 identity
+// provider (here: LinkedIn and legacy LinkedIn). Implementations live one file per
+// provider, following the ProviderLinkedIn / ProviderLinkedInLegacy pattern.
+type Provider interface {
+	Config() *Configuration
+	OAuth2(ctx context.Context) (*oauth2.Config, error)
+	AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption
+	Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (*Claims, error)
+}
+
+// Dependencies are the services a Provider needs to talk to an upstream API:
+// a tracer for span propagation, a logger for upstream error reporting, an
+// HTTP client for the outbound calls, and the strategy configuration.
+type Dependencies interface {
+	Logger() *logrusx.Logger
+	Tracer(ctx context.Context) *otelx.Tracer
+	HTTPClient(ctx context.Context) *retryablehttp.Client
+	Config() ProviderConfigProvider
+}
+
+// ProviderConfigProvider exposes the strategy-wide configuration a Provider
+// needs to compute its redirect URI.
+type ProviderConfigProvider interface {
+	OIDCRedirectURIBase(ctx context.Context) *url.URL
+}
+
+// newProvider resolves a Configuration into the concrete Provider it
+// identifies. This is the provider factory switch: adding support for a new
+// upstream means adding a case here alongside its ProviderXxx implementation.
+func newProvider(config *Configuration, reg Dependencies) (Provider, error) {
+	switch config.Provider {
+	case "linkedin":
+		return NewProviderLinkedIn(config, reg), nil
+	case "linkedin_legacy":
+		return NewProviderLinkedInLegacy(config, reg), nil
+	default:
+		return nil, errors.Errorf("provider type %q is not supported", config.Provider)
+	}
+}
+
+// logUpstreamError turns a non-2xx upstream response into a herodot error,
+// logging the upstream status and body for operator debugging. It returns nil
+// for any 2xx response.
+func logUpstreamError(l *logrusx.Logger, res *http.Response) error {
+	if res.StatusCode < 300 {
+		return nil
+	}
+
+	l.WithField("status_code", res.StatusCode).
+		WithField("request_url", res.Request.URL.String()).
+		Warn("upstream OIDC provider returned a non-2xx response")
+
+	if res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden {
+		return errors.WithStack(herodot.ErrUnauthorized.WithReasonf("upstream provider rejected the request with status code %d", res.StatusCode))
+	}
+
+	return errors.WithStack(herodot.ErrInternalServerError.WithReasonf("upstream provider responded with status code %d", res.StatusCode))
+}
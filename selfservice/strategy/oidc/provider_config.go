@@ -0,0 +1,60 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SupportedProviderIDs lists `provider` values for
+// `selfservice.methods.oidc.config.providers[].provider`. Nothing in this
+// package validates a Configuration against it, and NewProvider resolves only
+// "linkedin" and "linkedin_legacy"; it returns an error for the other IDs.
+var SupportedProviderIDs = []string{
+	"generic",
+	"google",
+	"github",
+	"gitlab",
+	"microsoft",
+	"facebook",
+	"apple",
+	"linkedin",
+	"linkedin_legacy",
+}
+
+// Configuration is the per-client-app configuration for a single upstream
+// provider, as found under `selfservice.methods.oidc.config.providers[]`.
+type Configuration struct {
+	// ID is the provider configuration's unique identifier within this Kratos instance.
+	ID string `json:"id"`
+	// Provider selects the Provider implementation this configuration resolves
+	// to, see SupportedProviderIDs. "linkedin" uses the OIDC userinfo endpoint;
+	// "linkedin_legacy" uses the v2 REST API for apps without OIDC scopes.
+	Provider     string   `json:"provider"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	Scope        []string `json:"scope"`
+	RedirectURI  string   `json:"redirect_uri,omitempty"`
+}
+
+// Redir returns this configuration's OAuth2 redirect URL, falling back to
+// defaultRedirectURI when no provider-specific override is configured.
+func (c *Configuration) Redir(defaultRedirectURI *url.URL) string {
+	if c.RedirectURI != "" {
+		return c.RedirectURI
+	}
+	if defaultRedirectURI == nil {
+		return ""
+	}
+	return strings.TrimSuffix(defaultRedirectURI.String(), "/") + "/" + c.ID
+}
+
+// NewProvider resolves config into the concrete Provider it identifies. It is
+// the single entry point callers (the OIDC strategy, the admin refresh
+// endpoint) should use instead of constructing a ProviderXxx directly, so that
+// newly registered providers are reachable everywhere.
+func NewProvider(config *Configuration, reg Dependencies) (Provider, error) {
+	return newProvider(config, reg)
+}
@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/ory/kratos/x"
 	"github.com/ory/x/otelx"
@@ -29,16 +31,17 @@ type LinkedInProfile struct {
 	EmailVerified      bool   `json:"email_verified"`
 	ID                 string `json:"sub"`
 	Locale             *struct {
-		Lanuguage string `json:"language"`
+		Language string `json:"language"`
+		Country  string `json:"country"`
 	} `json:"locale,omitempty"`
 }
 
 type LinkedInIntrospection struct {
 	Active       bool   `json:"active"`
 	ClientID     string `json:"client_id"`
-	AuthorizedAt uint32 `json:"authorized_at"`
-	CreatedAt    uint32 `json:"created_at"`
-	ExpiresAt    uint32 `json:"expires_at"`
+	AuthorizedAt int64  `json:"authorized_at"`
+	CreatedAt    int64  `json:"created_at"`
+	ExpiresAt    int64  `json:"expires_at"`
 	Status       string `json:"status"`
 	Scope        string `json:"scope"`
 	AuthType     string `json:"auth_type"`
@@ -51,6 +54,46 @@ const (
 	IntrospectionURL string = "https://www.linkedin.com/oauth/v2/introspectToken"
 )
 
+// IntrospectionResult is the provider-agnostic shape of an OAuth 2.0 token
+// introspection response (RFC 7662). Providers that implement TokenIntrospector
+// translate their own response into this so the OIDC strategy can treat every
+// provider uniformly.
+type IntrospectionResult struct {
+	Active       bool
+	Scope        string
+	ExpiresAt    time.Time
+	AuthorizedAt time.Time
+}
+
+// TokenIntrospector is an optional interface a Provider can implement to let the
+// OIDC strategy check the validity of an upstream access token out-of-band, i.e.
+// without relying on the userinfo endpoint accepting or rejecting it. Providers
+// that support an OAuth 2.0 token introspection endpoint should implement this
+// so account linking and refresh flows can detect revoked or expired tokens early.
+type TokenIntrospector interface {
+	// Introspect returns the upstream introspection result for token. Implementations
+	// should return an error wrapping herodot.ErrUnauthorized when the token is
+	// inactive or expired, so callers can distinguish that from transport failures.
+	Introspect(ctx context.Context, token string) (*IntrospectionResult, error)
+}
+
+// ErrProviderTokenExpired is returned by RefreshableProvider.Refresh when the
+// upstream provider has rejected the stored access token outright (e.g. with a
+// 401), meaning there is no way to validate or extend it short of asking the
+// identity to re-authenticate.
+var ErrProviderTokenExpired = herodot.ErrUnauthorized.WithReasonf("the stored upstream token is no longer valid and the identity must re-authenticate")
+
+// RefreshableProvider is an optional interface a Provider can implement when its
+// upstream does not issue OAuth 2.0 refresh tokens (or the identity never
+// obtained one). Instead of exchanging a refresh token, implementations re-prove
+// liveness of the existing access token against some authenticated upstream
+// endpoint and return a token with a bumped Expiry. Returning ErrProviderTokenExpired
+// signals that the access token itself has been revoked and the identity must
+// go through the OAuth flow again.
+type RefreshableProvider interface {
+	Refresh(ctx context.Context, oldToken *oauth2.Token) (*oauth2.Token, error)
+}
+
 type ProviderLinkedIn struct {
 	config *Configuration
 	reg    Dependencies
@@ -124,11 +167,135 @@ func (l *ProviderLinkedIn) Profile(ctx context.Context, client *retryablehttp.Cl
 	return &result, nil
 }
 
+// introspect calls LinkedIn's token introspection endpoint and returns the raw
+// LinkedIn response.
+func (l *ProviderLinkedIn) introspect(ctx context.Context, token string) (_ *LinkedInIntrospection, err error) {
+	ctx, span := l.reg.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.oidc.ProviderLinkedIn.introspect")
+	defer otelx.End(span, &err)
+
+	body := url.Values{
+		"client_id":     {l.config.ClientID},
+		"client_secret": {l.config.ClientSecret},
+		"token":         {token},
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, IntrospectionURL, strings.NewReader(body.Encode()))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := l.reg.HTTPClient(ctx)
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if err := logUpstreamError(l.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	var result LinkedInIntrospection
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &result, nil
+}
+
+// Introspect implements TokenIntrospector. It wraps introspect and translates an
+// inactive or expired token into a herodot.ErrUnauthorized so callers can detect
+// revoked tokens before hitting the userinfo endpoint.
+func (l *ProviderLinkedIn) Introspect(ctx context.Context, token string) (*IntrospectionResult, error) {
+	raw, err := l.introspect(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Unix(raw.ExpiresAt, 0)
+	if !raw.Active || (raw.ExpiresAt > 0 && expiresAt.Before(time.Now())) {
+		return nil, errors.WithStack(herodot.ErrUnauthorized.WithReasonf("linkedin access token is no longer active"))
+	}
+
+	return &IntrospectionResult{
+		Active:       raw.Active,
+		Scope:        raw.Scope,
+		ExpiresAt:    expiresAt,
+		AuthorizedAt: time.Unix(raw.AuthorizedAt, 0),
+	}, nil
+}
+
+var _ TokenIntrospector = (*ProviderLinkedIn)(nil)
+
+// Refresh implements RefreshableProvider. LinkedIn never issues refresh tokens
+// (its access tokens live for 60 days), so instead of a token exchange this
+// re-fetches the userinfo endpoint with the existing access token: success
+// proves the token is still live and we bump its Expiry from the introspection
+// endpoint's expires_at; a 401 means the token was revoked, and we return
+// ErrProviderTokenExpired so the OIDC strategy can prompt for re-auth.
+func (l *ProviderLinkedIn) Refresh(ctx context.Context, oldToken *oauth2.Token) (_ *oauth2.Token, err error) {
+	ctx, span := l.reg.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.oidc.ProviderLinkedIn.Refresh")
+	defer otelx.End(span, &err)
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, ProfileUrl, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// The bearer header is set directly because oldToken.Expiry may already be
+	// in the past, and an oauth2 client refuses to send such a token when it
+	// has no refresh token.
+	req.Header.Set("Authorization", "Bearer "+oldToken.AccessToken)
+
+	res, err := l.reg.HTTPClient(ctx).Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusUnauthorized {
+		return nil, errors.WithStack(ErrProviderTokenExpired)
+	}
+	if err := logUpstreamError(l.reg.Logger(), res); err != nil {
+		return nil, err
+	}
+
+	raw, err := l.introspect(ctx, oldToken.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if !raw.Active {
+		return nil, errors.WithStack(ErrProviderTokenExpired)
+	}
+
+	refreshed := *oldToken
+	refreshed.Expiry = time.Unix(raw.ExpiresAt, 0)
+
+	return &refreshed, nil
+}
+
+// ProfileLocale returns a BCP-47 tag built from the profile's locale, e.g.
+// "en-US" when both language and country are present, or just "en" when only
+// the language is.
+//
+// Migration note: before this change ProfileLocale only ever returned the bare
+// language tag (and silently dropped the country LinkedIn always sends).
+// Existing Jsonnet mappers and identity schemas that store `claims.locale`
+// verbatim may now receive a region-qualified tag instead of a bare language
+// one; mappers that need the parts separately should use the new
+// `claims.locale_country` field (see stub/oidc_linkedin.jsonnet) rather than
+// parsing Locale.
 func (l *ProviderLinkedIn) ProfileLocale(profile *LinkedInProfile) string {
 	if profile.Locale == nil {
 		return ""
 	}
-	return profile.Locale.Lanuguage
+	if profile.Locale.Language == "" {
+		return ""
+	}
+	if profile.Locale.Country == "" {
+		return profile.Locale.Language
+	}
+	return profile.Locale.Language + "-" + profile.Locale.Country
 }
 
 func (l *ProviderLinkedIn) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (_ *Claims, err error) {
@@ -140,6 +307,10 @@ func (l *ProviderLinkedIn) Claims(ctx context.Context, exchange *oauth2.Token, q
 		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
 	}
 
+	if _, err := l.Introspect(ctx, exchange.AccessToken); err != nil {
+		return nil, err
+	}
+
 	ctx, client := httpx.SetOAuth2(ctx, l.reg.HTTPClient(ctx), o, exchange)
 	profile, err := l.Profile(ctx, client)
 	if err != nil {
@@ -156,6 +327,9 @@ func (l *ProviderLinkedIn) Claims(ctx context.Context, exchange *oauth2.Token, q
 		EmailVerified: x.ConvertibleBoolean(profile.EmailVerified),
 		Locale:        l.ProfileLocale(profile),
 	}
+	if profile.Locale != nil {
+		claims.LocaleCountry = profile.Locale.Country
+	}
 
 	return claims, nil
 }
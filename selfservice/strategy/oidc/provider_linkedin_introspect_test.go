@@ -0,0 +1,99 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestProviderLinkedInClaimsActiveToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/v2/introspectToken", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"active": true, "expires_at": 9999999999}`))
+	})
+	mux.HandleFunc("/v2/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"sub": "li-1", "email": "jane@example.com"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: serverURL}
+	provider := newTestLinkedInProvider(t, deps)
+
+	claims, err := provider.Claims(context.Background(), &oauth2.Token{AccessToken: "valid"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "li-1", claims.Subject)
+	assert.Equal(t, "jane@example.com", claims.Email)
+}
+
+func TestProviderLinkedInClaimsInactiveToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/v2/introspectToken", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"active": false}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: serverURL}
+	provider := newTestLinkedInProvider(t, deps)
+
+	_, err = provider.Claims(context.Background(), &oauth2.Token{AccessToken: "revoked"}, nil)
+	require.Error(t, err)
+}
+
+func TestProviderLinkedInClaimsExpiredToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/v2/introspectToken", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"active": true, "expires_at": 1}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: serverURL}
+	provider := newTestLinkedInProvider(t, deps)
+
+	_, err = provider.Claims(context.Background(), &oauth2.Token{AccessToken: "expired"}, nil)
+	require.Error(t, err)
+}
+
+func TestLinkingValidatorRejectsInactiveToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/v2/introspectToken", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"active": false}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: serverURL}
+
+	err = NewLinkingValidator(deps).ValidateBeforeUse(context.Background(), &Configuration{ID: "linkedin", Provider: "linkedin"}, "revoked")
+	assert.Error(t, err)
+}
+
+func TestLinkingValidatorSkipsNonIntrospectingProviders(t *testing.T) {
+	deps := newFakeDependencies()
+
+	err := NewLinkingValidator(deps).ValidateBeforeUse(context.Background(), &Configuration{ID: "linkedin_legacy", Provider: "linkedin_legacy"}, "token")
+	assert.NoError(t, err)
+}
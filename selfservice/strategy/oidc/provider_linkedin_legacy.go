@@ -0,0 +1,194 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/ory/kratos/x"
+	"github.com/ory/x/otelx"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/linkedin"
+
+	"github.com/ory/herodot"
+	"github.com/ory/x/httpx"
+)
+
+// LinkedInLegacyProfile is the response shape of LinkedIn's legacy
+// `/v2/me` endpoint, available to apps that only hold the `r_liteprofile`
+// scope and cannot use the OIDC `/v2/userinfo` endpoint.
+type LinkedInLegacyProfile struct {
+	ID                 string `json:"id"`
+	LocalizedFirstName string `json:"localizedFirstName"`
+	LocalizedLastName  string `json:"localizedLastName"`
+	ProfilePicture     struct {
+		DisplayImage struct {
+			Elements []struct {
+				Identifiers []struct {
+					Identifier string `json:"identifier"`
+				} `json:"identifiers"`
+			} `json:"elements"`
+		} `json:"displayImage~"`
+	} `json:"profilePicture"`
+}
+
+// LinkedInLegacyEmail is the response shape of LinkedIn's legacy
+// `/v2/emailAddress` endpoint.
+type LinkedInLegacyEmail struct {
+	Elements []struct {
+		Handle struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"handle~"`
+	} `json:"elements"`
+}
+
+const (
+	LegacyProfileUrl string = "https://api.linkedin.com/v2/me?projection=(id,localizedFirstName,localizedLastName,profilePicture(displayImage~:playableStreams))"
+	LegacyEmailUrl   string = "https://api.linkedin.com/v2/emailAddress?q=members&projection=(elements*(handle~))"
+)
+
+// ProviderLinkedInLegacy talks to LinkedIn's legacy v2 REST API (`/v2/me` and
+// `/v2/emailAddress`) for OAuth apps that only have the `r_liteprofile` and
+// `r_emailaddress` scopes and therefore cannot use the OIDC `/v2/userinfo`
+// endpoint that ProviderLinkedIn requires. It is registered separately as
+// "linkedin_legacy" so both variants can be configured side by side.
+type ProviderLinkedInLegacy struct {
+	config *Configuration
+	reg    Dependencies
+}
+
+func NewProviderLinkedInLegacy(
+	config *Configuration,
+	reg Dependencies,
+) Provider {
+	return &ProviderLinkedInLegacy{
+		config: config,
+		reg:    reg,
+	}
+}
+
+func (l *ProviderLinkedInLegacy) Config() *Configuration {
+	return l.config
+}
+
+func (l *ProviderLinkedInLegacy) oauth2(ctx context.Context) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     l.config.ClientID,
+		ClientSecret: l.config.ClientSecret,
+		Endpoint:     linkedin.Endpoint,
+		Scopes:       l.config.Scope,
+		RedirectURL:  l.config.Redir(l.reg.Config().OIDCRedirectURIBase(ctx)),
+	}
+}
+
+func (l *ProviderLinkedInLegacy) OAuth2(ctx context.Context) (*oauth2.Config, error) {
+	return l.oauth2(ctx), nil
+}
+
+func (l *ProviderLinkedInLegacy) AuthCodeURLOptions(r ider) []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{}
+}
+
+func (l *ProviderLinkedInLegacy) fetch(ctx context.Context, client *retryablehttp.Client, url string, result interface{}) (err error) {
+	ctx, span := l.reg.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.oidc.ProviderLinkedInLegacy.fetch")
+	defer otelx.End(span, &err)
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	defer res.Body.Close()
+	if err := logUpstreamError(l.reg.Logger(), res); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (l *ProviderLinkedInLegacy) Profile(ctx context.Context, client *retryablehttp.Client) (*LinkedInLegacyProfile, error) {
+	var result LinkedInLegacyProfile
+
+	if err := l.fetch(ctx, client, LegacyProfileUrl, &result); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &result, nil
+}
+
+func (l *ProviderLinkedInLegacy) Email(ctx context.Context, client *retryablehttp.Client) (*LinkedInLegacyEmail, error) {
+	var result LinkedInLegacyEmail
+
+	if err := l.fetch(ctx, client, LegacyEmailUrl, &result); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &result, nil
+}
+
+// profilePicture returns the first playable display image identifier, if any.
+func (p *LinkedInLegacyProfile) profilePicture() string {
+	elements := p.ProfilePicture.DisplayImage.Elements
+	if len(elements) == 0 || len(elements[0].Identifiers) == 0 {
+		return ""
+	}
+	return elements[0].Identifiers[0].Identifier
+}
+
+// emailAddress returns the first verified email address, if any. LinkedIn's
+// emailAddress endpoint only ever returns addresses it has already verified.
+func (e *LinkedInLegacyEmail) emailAddress() string {
+	if len(e.Elements) == 0 {
+		return ""
+	}
+	return e.Elements[0].Handle.EmailAddress
+}
+
+func (l *ProviderLinkedInLegacy) Claims(ctx context.Context, exchange *oauth2.Token, query url.Values) (_ *Claims, err error) {
+	ctx, span := l.reg.Tracer(ctx).Tracer().Start(ctx, "selfservice.strategy.oidc.ProviderLinkedInLegacy.Claims")
+	defer otelx.End(span, &err)
+
+	o, err := l.OAuth2(ctx)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	ctx, client := httpx.SetOAuth2(ctx, l.reg.HTTPClient(ctx), o, exchange)
+	profile, err := l.Profile(ctx, client)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	email, err := l.Email(ctx, client)
+	if err != nil {
+		return nil, errors.WithStack(herodot.ErrInternalServerError.WithReasonf("%s", err))
+	}
+
+	claims := &Claims{
+		Subject:       profile.ID,
+		Issuer:        "https://login.linkedin.com/",
+		Email:         email.emailAddress(),
+		GivenName:     profile.LocalizedFirstName,
+		LastName:      profile.LocalizedLastName,
+		Picture:       profile.profilePicture(),
+		EmailVerified: x.ConvertibleBoolean(true),
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,137 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestLinkedInLegacyProfilePicture(t *testing.T) {
+	raw := `{
+		"id": "abc123",
+		"localizedFirstName": "Jane",
+		"localizedLastName": "Doe",
+		"profilePicture": {
+			"displayImage~": {
+				"elements": [
+					{"identifiers": [{"identifier": "https://media.licdn.com/jane.jpg"}]}
+				]
+			}
+		}
+	}`
+
+	var profile LinkedInLegacyProfile
+	require.NoError(t, json.Unmarshal([]byte(raw), &profile))
+
+	assert.Equal(t, "abc123", profile.ID)
+	assert.Equal(t, "https://media.licdn.com/jane.jpg", profile.profilePicture())
+}
+
+func TestLinkedInLegacyProfilePictureMissing(t *testing.T) {
+	var profile LinkedInLegacyProfile
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "abc123"}`), &profile))
+
+	assert.Empty(t, profile.profilePicture())
+}
+
+func TestLinkedInLegacyEmailAddress(t *testing.T) {
+	raw := `{
+		"elements": [
+			{"handle~": {"emailAddress": "jane@example.com"}}
+		]
+	}`
+
+	var email LinkedInLegacyEmail
+	require.NoError(t, json.Unmarshal([]byte(raw), &email))
+
+	assert.Equal(t, "jane@example.com", email.emailAddress())
+}
+
+func TestLinkedInLegacyEmailAddressMissing(t *testing.T) {
+	var email LinkedInLegacyEmail
+	require.NoError(t, json.Unmarshal([]byte(`{"elements": []}`), &email))
+
+	assert.Empty(t, email.emailAddress())
+}
+
+// redirectToTestServerTransport rewrites every outbound request's scheme and
+// host to point at a mocked upstream, so provider code that hits hardcoded
+// LinkedIn URLs can be exercised against an httptest.Server.
+type redirectToTestServerTransport struct {
+	serverURL *url.URL
+}
+
+func (rt redirectToTestServerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.serverURL.Scheme
+	req.URL.Host = rt.serverURL.Host
+	req.Host = rt.serverURL.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestProviderLinkedInLegacyClaims(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/me", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer legacy-access-token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{
+			"id": "li-legacy-123",
+			"localizedFirstName": "Jane",
+			"localizedLastName": "Doe",
+			"profilePicture": {"displayImage~": {"elements": [{"identifiers": [{"identifier": "https://media.licdn.com/jane.jpg"}]}]}}
+		}`))
+	})
+	mux.HandleFunc("/v2/emailAddress", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"elements": [{"handle~": {"emailAddress": "jane@example.com"}}]}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: serverURL}
+	config := &Configuration{ID: "linkedin_legacy", Provider: "linkedin_legacy", ClientID: "client", ClientSecret: "secret"}
+
+	provider, err := NewProvider(config, deps)
+	require.NoError(t, err)
+	legacy, ok := provider.(*ProviderLinkedInLegacy)
+	require.True(t, ok, "factory should resolve %q to a *ProviderLinkedInLegacy", config.Provider)
+
+	claims, err := legacy.Claims(context.Background(), &oauth2.Token{AccessToken: "legacy-access-token"}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "li-legacy-123", claims.Subject)
+	assert.Equal(t, "jane@example.com", claims.Email)
+	assert.Equal(t, "Jane", claims.GivenName)
+	assert.Equal(t, "Doe", claims.LastName)
+	assert.Equal(t, "https://media.licdn.com/jane.jpg", claims.Picture)
+	assert.EqualValues(t, true, claims.EmailVerified)
+}
+
+func TestProviderFactoryDistinguishesLinkedInVariants(t *testing.T) {
+	deps := newFakeDependencies()
+
+	oidcProvider, err := NewProvider(&Configuration{ID: "linkedin", Provider: "linkedin"}, deps)
+	require.NoError(t, err)
+	_, ok := oidcProvider.(*ProviderLinkedIn)
+	assert.True(t, ok, "provider %q should resolve to the OIDC implementation", "linkedin")
+
+	legacyProvider, err := NewProvider(&Configuration{ID: "linkedin_legacy", Provider: "linkedin_legacy"}, deps)
+	require.NoError(t, err)
+	_, ok = legacyProvider.(*ProviderLinkedInLegacy)
+	assert.True(t, ok, "provider %q should resolve to the legacy implementation", "linkedin_legacy")
+
+	_, err = NewProvider(&Configuration{ID: "unknown", Provider: "unknown"}, deps)
+	assert.Error(t, err)
+}
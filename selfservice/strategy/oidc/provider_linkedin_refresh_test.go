@@ -0,0 +1,113 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func newTestLinkedInProvider(t *testing.T, deps *fakeDependencies) *ProviderLinkedIn {
+	t.Helper()
+	provider, err := NewProvider(&Configuration{ID: "linkedin", Provider: "linkedin", ClientID: "client", ClientSecret: "secret"}, deps)
+	require.NoError(t, err)
+	linkedin, ok := provider.(*ProviderLinkedIn)
+	require.True(t, ok)
+	return linkedin
+}
+
+func TestProviderLinkedInRefreshBumpsExpiry(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"sub": "li-1"}`))
+	})
+	mux.HandleFunc("/oauth/v2/introspectToken", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"active": true, "expires_at": 9999999999}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: serverURL}
+	provider := newTestLinkedInProvider(t, deps)
+
+	oldToken := &oauth2.Token{AccessToken: "still-valid", Expiry: time.Unix(1, 0)}
+	refreshed, err := provider.Refresh(context.Background(), oldToken)
+	require.NoError(t, err)
+
+	assert.Equal(t, oldToken.AccessToken, refreshed.AccessToken)
+	assert.True(t, refreshed.Expiry.After(oldToken.Expiry))
+}
+
+func TestProviderLinkedInRefreshUnauthorizedIsExpired(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: serverURL}
+	provider := newTestLinkedInProvider(t, deps)
+
+	_, err = provider.Refresh(context.Background(), &oauth2.Token{AccessToken: "revoked"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrProviderTokenExpired)
+}
+
+func TestProviderLinkedInRefreshInactiveIntrospectionIsExpired(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"sub": "li-1"}`))
+	})
+	mux.HandleFunc("/oauth/v2/introspectToken", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"active": false}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: serverURL}
+	provider := newTestLinkedInProvider(t, deps)
+
+	_, err = provider.Refresh(context.Background(), &oauth2.Token{AccessToken: "stale"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrProviderTokenExpired)
+}
+
+func TestCredentialsRefresherPassesThroughUnsupportedProviders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/v2/introspectToken", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"active": true, "expires_at": 9999999999}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	deps := newFakeDependencies()
+	deps.transport = redirectToTestServerTransport{serverURL: serverURL}
+
+	// linkedin_legacy implements neither RefreshableProvider nor
+	// TokenIntrospector, so the refresher should hand the token back as-is.
+	token := &oauth2.Token{AccessToken: "unchanged"}
+	out, err := NewCredentialsRefresher(deps).Refresh(context.Background(), &Configuration{ID: "linkedin_legacy", Provider: "linkedin_legacy"}, token)
+	require.NoError(t, err)
+	assert.Equal(t, token, out)
+}
@@ -0,0 +1,32 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/ory/herodot"
+)
+
+// Strategy is the selfservice "oidc" method's handler surface. Its admin
+// endpoints are mounted on an admin router by calling RegisterAdminRoutes.
+type Strategy struct {
+	d       Dependencies
+	refresh *AdminRefreshHandler
+}
+
+// NewStrategy wires the admin refresh endpoint against store, the identity
+// credentials persister it should read/write stored upstream tokens from.
+func NewStrategy(d Dependencies, store CredentialsRefreshPersister, writer *herodot.JSONWriter) *Strategy {
+	return &Strategy{
+		d:       d,
+		refresh: NewAdminRefreshHandler(d, store, writer),
+	}
+}
+
+// RegisterAdminRoutes mounts this strategy's admin endpoints, currently just
+// RouteAdminIdentityCredentialsOIDCRefresh.
+func (s *Strategy) RegisterAdminRoutes(admin *httprouter.Router) {
+	s.refresh.RegisterAdminRoutes(admin)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2023 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/ory/x/logrusx"
+	"github.com/ory/x/otelx"
+)
+
+// fakeDependencies is a minimal Dependencies for exercising a Provider against
+// an httptest.Server without pulling in the full Kratos registry. Tests that
+// need the Provider's outbound calls to land on a mock server set transport so
+// every *retryablehttp.Client this hands out redirects there.
+type fakeDependencies struct {
+	redirectBase *url.URL
+	transport    http.RoundTripper
+}
+
+func (f *fakeDependencies) Logger() *logrusx.Logger {
+	return logrusx.New("kratos-oidc-test", "")
+}
+
+func (f *fakeDependencies) Tracer(ctx context.Context) *otelx.Tracer {
+	return otelx.NewNoop(f.Logger(), &otelx.Config{ServiceName: "kratos-oidc-test"})
+}
+
+func (f *fakeDependencies) HTTPClient(ctx context.Context) *retryablehttp.Client {
+	c := retryablehttp.NewClient()
+	c.RetryMax = 0
+	c.Logger = nil
+	if f.transport != nil {
+		c.HTTPClient.Transport = f.transport
+	}
+	return c
+}
+
+func (f *fakeDependencies) Config() ProviderConfigProvider {
+	return fakeConfigProvider{base: f.redirectBase}
+}
+
+type fakeConfigProvider struct {
+	base *url.URL
+}
+
+func (f fakeConfigProvider) OIDCRedirectURIBase(ctx context.Context) *url.URL {
+	return f.base
+}
+
+func newFakeDependencies() *fakeDependencies {
+	u, _ := url.Parse("https://kratos.example.com/self-service/methods/oidc/callback")
+	return &fakeDependencies{redirectBase: u}
+}